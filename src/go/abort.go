@@ -0,0 +1,86 @@
+package s3_integrity_checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// AbortedUpload records one in-progress multipart upload that
+// ListAndAbortStaleUploads found and either aborted or would have aborted in
+// dry-run mode.
+type AbortedUpload struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+	DryRun    bool
+}
+
+// ListAndAbortStaleUploads pages through ListMultipartUploads for bucket
+// (optionally restricted to prefix) and aborts every upload initiated more
+// than olderThan ago. With dryRun true it calls no AbortMultipartUpload and
+// returns the matching uploads with AbortedUpload.DryRun set, so callers can
+// report what would be aborted.
+func ListAndAbortStaleUploads(ctx context.Context, client *s3.Client, bucket, prefix string, olderThan time.Duration, dryRun bool) ([]AbortedUpload, error) {
+	cutoff := time.Now().Add(-olderThan)
+
+	var aborted []AbortedUpload
+	var keyMarker, uploadIDMarker *string
+
+	for {
+		listInput := &s3.ListMultipartUploadsInput{
+			Bucket: aws.String(bucket),
+		}
+		if prefix != "" {
+			listInput.Prefix = aws.String(prefix)
+		}
+		if keyMarker != nil {
+			listInput.KeyMarker = keyMarker
+		}
+		if uploadIDMarker != nil {
+			listInput.UploadIdMarker = uploadIDMarker
+		}
+
+		listResp, err := client.ListMultipartUploads(ctx, listInput)
+		if err != nil {
+			return aborted, fmt.Errorf("failed to list multipart uploads: %v", err)
+		}
+
+		for _, upload := range listResp.Uploads {
+			if upload.Initiated == nil || upload.Initiated.After(cutoff) {
+				continue
+			}
+
+			record := AbortedUpload{
+				Key:       aws.ToString(upload.Key),
+				UploadID:  aws.ToString(upload.UploadId),
+				Initiated: *upload.Initiated,
+				DryRun:    dryRun,
+			}
+
+			if !dryRun {
+				_, err := client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+					Bucket:   aws.String(bucket),
+					Key:      upload.Key,
+					UploadId: upload.UploadId,
+				})
+				if err != nil {
+					return aborted, fmt.Errorf("failed to abort upload %s for key %s: %v", record.UploadID, record.Key, err)
+				}
+			}
+
+			aborted = append(aborted, record)
+		}
+
+		if !aws.ToBool(listResp.IsTruncated) {
+			break
+		}
+		keyMarker = listResp.NextKeyMarker
+		uploadIDMarker = listResp.NextUploadIdMarker
+	}
+
+	return aborted, nil
+}
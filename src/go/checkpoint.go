@@ -0,0 +1,263 @@
+package s3_integrity_checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// checkpointFingerprintBytes is how many bytes from the start of the source
+// file are hashed into checkpointState.ContentFingerprint. Size+mtime alone
+// can't tell a truncated-then-rewritten file from the original, so a content
+// fingerprint catches the common case cheaply without rehashing the whole
+// file on every resume.
+const checkpointFingerprintBytes = 64 * 1024
+
+// computeContentFingerprint returns the CRC32 of the first n bytes of the
+// file at path (or of the whole file if it's shorter than n).
+func computeContentFingerprint(path string, n int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, n))
+	if err != nil {
+		return "", err
+	}
+	return ComputeCRC32(data), nil
+}
+
+// checkpointPart records everything needed to reconcile a previously
+// uploaded part against S3's view of the in-progress upload (via ListParts)
+// without re-reading the source.
+type checkpointPart struct {
+	PartNumber int32  `json:"partNumber"`
+	Size       int64  `json:"size"`
+	Checksum   string `json:"checksum"`
+	ETag       string `json:"eTag"`
+}
+
+// checkpointState is the on-disk representation of an in-progress multipart
+// upload, modeled on the Aliyun OSS `uploadFileWithCp` checkpoint file. It is
+// written to MultipartUploadInput.CheckpointPath so an interrupted upload can be resumed
+// without re-uploading parts S3 already has.
+type checkpointState struct {
+	Bucket             string           `json:"bucket"`
+	Key                string           `json:"key"`
+	FilePath           string           `json:"filePath"`
+	Region             string           `json:"region"`
+	EndpointURL        string           `json:"endpointUrl"`
+	Profile            string           `json:"profile"`
+	UploadID           string           `json:"uploadId"`
+	PartSize           int64            `json:"partSize"`
+	ChecksumAlgorithm  string           `json:"checksumAlgorithm"`
+	SourceSize         int64            `json:"sourceSize"`
+	SourceModTime      int64            `json:"sourceModTime"` // Unix nanoseconds
+	ContentFingerprint string           `json:"contentFingerprint"`
+	CompletedParts     []checkpointPart `json:"completedParts"`
+}
+
+// matchesSource reports whether this checkpoint was recorded for the same
+// source file the caller is now uploading. Size and mtime alone would miss a
+// file truncated and rewritten to the same length within the same second, so
+// this also checks a content fingerprint of the first
+// checkpointFingerprintBytes bytes.
+//
+// PartSize must match too: the resume path skips already-uploaded bytes by
+// multiplying a part count by input.PartSize, so resuming with a different
+// part size would skip the wrong number of bytes and corrupt the upload.
+// input.PartSize is passed in already resolved (AutoPartSize/MaxParts have
+// been applied by the time this is called), so comparing it also covers a
+// changed MaxParts without needing to persist MaxParts separately.
+func (c *checkpointState) matchesSource(input MultipartUploadInput, size int64, modTime time.Time, fingerprint string) bool {
+	return c.Bucket == input.Bucket &&
+		c.Key == input.Key &&
+		c.FilePath == input.FilePath &&
+		c.ChecksumAlgorithm == input.ChecksumAlgorithm &&
+		c.PartSize == input.PartSize &&
+		c.SourceSize == size &&
+		c.SourceModTime == modTime.UnixNano() &&
+		c.ContentFingerprint == fingerprint
+}
+
+func loadCheckpoint(path string) (*checkpointState, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state checkpointState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("corrupt checkpoint file %s: %v", path, err)
+	}
+	return &state, nil
+}
+
+// saveCheckpoint persists state atomically by writing to a temp file in the
+// same directory and renaming over the destination, so a crash mid-write
+// never leaves a half-written checkpoint behind.
+func saveCheckpoint(path string, state *checkpointState) error {
+	raw, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %v", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to commit checkpoint: %v", err)
+	}
+	return nil
+}
+
+func deleteCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %v", err)
+	}
+	return nil
+}
+
+// reconciledPart pairs a checkpoint part's PartInfo and completedPartRef so
+// the two can be sorted and trimmed together in reconcileCheckpoint.
+type reconciledPart struct {
+	info      PartInfo
+	completed completedPartRef
+}
+
+// reconcileCheckpoint lists the parts S3 currently has for uploadID and
+// intersects them with the checkpoint's recorded parts, so any part the
+// checkpoint believes is done but S3 does not have (e.g. because the
+// process died before the checkpoint write) is re-uploaded rather than
+// silently skipped.
+//
+// The resume path in MultipartUpload skips already-uploaded bytes by
+// counting returned parts, which is only correct if those parts are the
+// contiguous prefix 1..N of the source (uploads always assign part numbers
+// sequentially from 1). Concurrent part uploads can finish out of order,
+// and a part's result can be dropped entirely if a sibling part fails
+// around the same time, so the checkpoint can have gaps (e.g. parts
+// {1,2,4} recorded, 3 missing). Trimming to the contiguous prefix here, and
+// letting anything past the first gap be re-uploaded under the same part
+// numbers, keeps the byte-count skip correct.
+func reconcileCheckpoint(ctx context.Context, client *s3.Client, state *checkpointState, hasher partHasher) ([]PartInfo, []completedPartRef, error) {
+	listResp, err := client.ListParts(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(state.Bucket),
+		Key:      aws.String(state.Key),
+		UploadId: aws.String(state.UploadID),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list parts for resume: %v", err)
+	}
+
+	onServer := make(map[int32]string, len(listResp.Parts))
+	for _, part := range listResp.Parts {
+		if digest := hasher.GetFromPart(part); digest != nil {
+			onServer[*part.PartNumber] = *digest
+		}
+	}
+
+	var reconciled []reconciledPart
+	for _, cp := range state.CompletedParts {
+		serverChecksum, ok := onServer[cp.PartNumber]
+		if !ok || serverChecksum != cp.Checksum {
+			InfoLogger.Printf("Checkpoint part %d not confirmed on S3, will re-upload\n", cp.PartNumber)
+			continue
+		}
+		reconciled = append(reconciled, reconciledPart{
+			info: PartInfo{
+				PartNumber: cp.PartNumber,
+				Size:       cp.Size,
+				Checksum:   cp.Checksum,
+			},
+			completed: completedPartRef{
+				PartNumber: cp.PartNumber,
+				ETag:       cp.ETag,
+				Checksum:   cp.Checksum,
+			},
+		})
+	}
+
+	sort.Slice(reconciled, func(i, j int) bool {
+		return reconciled[i].info.PartNumber < reconciled[j].info.PartNumber
+	})
+
+	contiguous := 0
+	for _, r := range reconciled {
+		if r.info.PartNumber != int32(contiguous+1) {
+			break
+		}
+		contiguous++
+	}
+	if contiguous < len(reconciled) {
+		InfoLogger.Printf("Checkpoint has a gap after part %d; %d part(s) will be re-uploaded\n", contiguous, len(reconciled)-contiguous)
+	}
+	reconciled = reconciled[:contiguous]
+
+	partInfos := make([]PartInfo, 0, len(reconciled))
+	completed := make([]completedPartRef, 0, len(reconciled))
+	for _, r := range reconciled {
+		partInfos = append(partInfos, r.info)
+		completed = append(completed, r.completed)
+	}
+
+	return partInfos, completed, nil
+}
+
+// completedPartRef is the minimal subset of types.CompletedPart needed to
+// reconstruct it without importing the s3/types package into this file.
+type completedPartRef struct {
+	PartNumber int32
+	ETag       string
+	Checksum   string
+}
+
+// skipSourceBytes advances source past n already-uploaded bytes. Seekable
+// sources (notably *os.File) skip without reading; everything else falls
+// back to discarding the bytes, which still avoids buffering them.
+func skipSourceBytes(source io.Reader, n int64) error {
+	if n == 0 {
+		return nil
+	}
+	if seeker, ok := source.(io.Seeker); ok {
+		_, err := seeker.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, source, n)
+	return err
+}
+
+// ResumeMultipartUpload resumes a multipart upload previously started with
+// MultipartUploadInput.EnableCheckpoint set, using the checkpoint file at
+// checkpointPath to recover the bucket/key/upload ID and skip parts S3
+// already has.
+func ResumeMultipartUpload(ctx context.Context, checkpointPath string) (*UploadStatus, error) {
+	state, err := loadCheckpoint(checkpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %v", err)
+	}
+
+	input := MultipartUploadInput{
+		Bucket:            state.Bucket,
+		Key:               state.Key,
+		FilePath:          state.FilePath,
+		Region:            state.Region,
+		EndpointURL:       state.EndpointURL,
+		Profile:           state.Profile,
+		PartSize:          state.PartSize,
+		ChecksumAlgorithm: state.ChecksumAlgorithm,
+		CheckpointPath:    checkpointPath,
+		EnableCheckpoint:  true,
+	}
+
+	return MultipartUpload(ctx, input)
+}
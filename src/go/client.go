@@ -0,0 +1,78 @@
+package s3_integrity_checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// newS3Client builds an S3 client from the same region/profile/custom-endpoint
+// inputs MultipartUpload accepts, so management APIs (stale-upload cleanup,
+// listing) can be pointed at the same account and endpoint without
+// duplicating the profile-resolution and endpoint-override logic.
+//
+// region and endpointURL are overridden in place when the named profile
+// supplies its own values, mirroring MultipartUpload's behavior of
+// preferring profile settings over the caller-supplied defaults.
+func newS3Client(ctx context.Context, profile string, region, endpointURL *string, verbose bool) (*s3.Client, error) {
+	var cfgOptions []func(*config.LoadOptions) error
+
+	if profile != "" {
+		prof, err := getCredentialsFromProfile(profile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials from profile: %v", err)
+		}
+
+		if prof.region != "" {
+			*region = prof.region
+		}
+		if prof.endpointURL != "" {
+			*endpointURL = prof.endpointURL
+		}
+
+		if verbose {
+			InfoLogger.Printf("Using endpoint URL: %s\n", *endpointURL)
+			InfoLogger.Printf("Using region: %s\n", *region)
+		}
+
+		cfgOptions = append(cfgOptions, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			prof.accessKey,
+			prof.secretKey,
+			"",
+		)))
+	}
+
+	cfgOptions = append(cfgOptions, config.WithRegion(*region))
+
+	endpoint := *endpointURL
+	customResolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+		if endpoint != "" {
+			return aws.Endpoint{
+				PartitionID:       "aws",
+				URL:               endpoint,
+				SigningRegion:     region,
+				HostnameImmutable: true,
+			}, nil
+		}
+		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+	})
+	cfgOptions = append(cfgOptions, config.WithEndpointResolver(customResolver))
+
+	cfg, err := config.LoadDefaultConfig(ctx, cfgOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+	}
+
+	return s3.NewFromConfig(cfg), nil
+}
+
+// NewS3Client is the exported counterpart to newS3Client for callers outside
+// this package (notably the CLI's management subcommands) that don't need
+// the resolved region/endpoint back, only a ready-to-use client.
+func NewS3Client(ctx context.Context, profile, region, endpointURL string, verbose bool) (*s3.Client, error) {
+	return newS3Client(ctx, profile, &region, &endpointURL, verbose)
+}
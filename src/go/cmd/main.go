@@ -8,6 +8,7 @@ import (
 	s3_integrity_checks "s3-integrity-checks/src/go"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func main() {
@@ -22,6 +23,15 @@ func main() {
 	var uploadEmptyPart bool
 	var partIndicesStr string
 	var partSize int64
+	var checkpointPath string
+	var resume bool
+	var checksumAlgorithm string
+	var abortStale bool
+	var abortPrefix string
+	var abortOlderThan time.Duration
+	var dryRun bool
+	var maxWorkers int
+	var algorithmsStr string
 
 	flag.StringVar(&filePath, "file", "", "Path to the file to upload")
 	flag.StringVar(&text, "text", "", "Text content to upload")
@@ -34,10 +44,69 @@ func main() {
 	flag.BoolVar(&verbose, "v", false, "Enable verbose output (shorthand)")
 	flag.BoolVar(&uploadEmptyPart, "upload-empty-part", false, "Upload an empty part as the final part")
 	flag.StringVar(&partIndicesStr, "parts", "", "Comma-separated list of part indices to upload (e.g., '1,2,4')")
-	flag.Int64Var(&partSize, "part-size", s3_integrity_checks.DefaultPartSize, "Size of each part in bytes (minimum 5MB)")
+	flag.Int64Var(&partSize, "part-size", 0, "Size of each part in bytes (minimum 5MB). 0 auto-selects a part size from the input's total size")
+	flag.StringVar(&checkpointPath, "checkpoint", "", "Path to a checkpoint file for resumable uploads")
+	flag.BoolVar(&resume, "resume", false, "Resume an interrupted upload from --checkpoint")
+	flag.StringVar(&checksumAlgorithm, "checksum-algorithm", s3_integrity_checks.ChecksumAlgorithmCRC32, "Checksum algorithm to use: CRC32, CRC32C, SHA1, or SHA256")
+	flag.BoolVar(&abortStale, "abort-stale", false, "List and abort incomplete multipart uploads older than --older-than, then exit")
+	flag.StringVar(&abortPrefix, "prefix", "", "Restrict --abort-stale to keys with this prefix")
+	flag.DurationVar(&abortOlderThan, "older-than", 24*time.Hour, "Age threshold for --abort-stale (e.g. 24h, 30m)")
+	flag.BoolVar(&dryRun, "dry-run", false, "With --abort-stale, report what would be aborted without aborting")
+	flag.IntVar(&maxWorkers, "max-workers", 0, "Maximum number of parts to upload concurrently (default 4)")
+	flag.StringVar(&algorithmsStr, "algorithms", "", "Comma-separated whole-object digests to compute: crc32,sha256,md5 (default: all three)")
 
 	flag.Parse()
 
+	if abortStale {
+		if bucket == "" {
+			fmt.Println("Error: --bucket is required")
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		client, err := s3_integrity_checks.NewS3Client(context.Background(), profile, region, endpointURL, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		aborted, err := s3_integrity_checks.ListAndAbortStaleUploads(context.Background(), client, bucket, abortPrefix, abortOlderThan, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		verb := "Aborted"
+		if dryRun {
+			verb = "Would abort"
+		}
+		for _, a := range aborted {
+			fmt.Printf("%s: key=%s uploadId=%s initiated=%s\n", verb, a.Key, a.UploadID, a.Initiated.Format(time.RFC3339))
+		}
+		fmt.Printf("%s %d stale upload(s)\n", verb, len(aborted))
+		return
+	}
+
+	if resume {
+		if checkpointPath == "" {
+			fmt.Println("Error: --resume requires --checkpoint")
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		status, err := s3_integrity_checks.ResumeMultipartUpload(context.Background(), checkpointPath)
+		if err != nil {
+			if status != nil {
+				status.PrintSummary()
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		status.PrintSummary()
+		return
+	}
+
 	if bucket == "" {
 		fmt.Println("Error: --bucket is required")
 		flag.Usage()
@@ -82,18 +151,33 @@ func main() {
 		}
 	}
 
+	var algorithms []string
+	if algorithmsStr != "" {
+		for _, a := range strings.Split(algorithmsStr, ",") {
+			algorithms = append(algorithms, strings.TrimSpace(a))
+		}
+	}
+
 	// Create input configuration
 	input := s3_integrity_checks.MultipartUploadInput{
-		Bucket:          bucket,
-		Key:             key,
-		FilePath:        filePath,
-		EndpointURL:     endpointURL,
-		Region:          region,
-		Profile:         profile,
-		Verbose:         verbose,
-		UploadEmptyPart: uploadEmptyPart,
-		PartIndices:     partIndices,
-		PartSize:        partSize,
+		Bucket:            bucket,
+		Key:               key,
+		FilePath:          filePath,
+		EndpointURL:       endpointURL,
+		Region:            region,
+		Profile:           profile,
+		Verbose:           verbose,
+		UploadEmptyPart:   uploadEmptyPart,
+		PartIndices:       partIndices,
+		PartSize:          partSize,
+		ChecksumAlgorithm: checksumAlgorithm,
+		MaxWorkers:        maxWorkers,
+		Algorithms:        algorithms,
+	}
+
+	if checkpointPath != "" {
+		input.CheckpointPath = checkpointPath
+		input.EnableCheckpoint = true
 	}
 
 	// If text is provided, convert it to bytes
@@ -112,4 +196,12 @@ func main() {
 	}
 
 	status.PrintSummary()
+	for _, alg := range []string{"crc32", "sha256", "md5"} {
+		if sum, ok := status.Checksums[alg]; ok {
+			fmt.Printf("%s: %s\n", alg, sum)
+		}
+	}
+	if status.CompositeETag != "" {
+		fmt.Printf("composite ETag: %s\n", status.CompositeETag)
+	}
 }
@@ -0,0 +1,137 @@
+package s3_integrity_checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ListIncompleteInput pages through ListMultipartUploads one request at a
+// time, mirroring S3's own KeyMarker/UploadIdMarker continuation protocol
+// (as surfaced by, e.g., goamz's listMultiResp) instead of aggregating every
+// page internally, so callers can drive pagination themselves.
+type ListIncompleteInput struct {
+	Bucket         string
+	Prefix         string
+	KeyMarker      string
+	UploadIDMarker string
+	// MaxUploads caps how many uploads this single page returns. Defaults to
+	// S3's own page size (1000) when 0.
+	MaxUploads int32
+}
+
+// IncompleteUpload is one in-progress multipart upload, as returned by
+// ListIncompleteUploads.
+type IncompleteUpload struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+}
+
+// ListIncompleteOutput is one page of ListIncompleteUploads results. When
+// IsTruncated is true, NextKeyMarker/NextUploadIDMarker should be fed back
+// into ListIncompleteInput to fetch the next page.
+type ListIncompleteOutput struct {
+	Uploads            []IncompleteUpload
+	IsTruncated        bool
+	NextKeyMarker      string
+	NextUploadIDMarker string
+}
+
+// ListIncompleteUploads wraps ListMultipartUploads for a single page of
+// results.
+func ListIncompleteUploads(ctx context.Context, client *s3.Client, input ListIncompleteInput) (*ListIncompleteOutput, error) {
+	listInput := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(input.Bucket),
+	}
+	if input.Prefix != "" {
+		listInput.Prefix = aws.String(input.Prefix)
+	}
+	if input.KeyMarker != "" {
+		listInput.KeyMarker = aws.String(input.KeyMarker)
+	}
+	if input.UploadIDMarker != "" {
+		listInput.UploadIdMarker = aws.String(input.UploadIDMarker)
+	}
+	if input.MaxUploads > 0 {
+		listInput.MaxUploads = aws.Int32(input.MaxUploads)
+	}
+
+	listResp, err := client.ListMultipartUploads(ctx, listInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list multipart uploads: %v", err)
+	}
+
+	output := &ListIncompleteOutput{
+		IsTruncated:        aws.ToBool(listResp.IsTruncated),
+		NextKeyMarker:      aws.ToString(listResp.NextKeyMarker),
+		NextUploadIDMarker: aws.ToString(listResp.NextUploadIdMarker),
+	}
+	for _, upload := range listResp.Uploads {
+		if upload.Initiated == nil {
+			continue
+		}
+		output.Uploads = append(output.Uploads, IncompleteUpload{
+			Key:       aws.ToString(upload.Key),
+			UploadID:  aws.ToString(upload.UploadId),
+			Initiated: *upload.Initiated,
+		})
+	}
+
+	return output, nil
+}
+
+// ListUploadParts wraps ListParts, returning every part S3 currently has
+// recorded for an in-progress upload.
+func ListUploadParts(ctx context.Context, client *s3.Client, bucket, key, uploadID string) ([]types.Part, error) {
+	listInput := &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	}
+	var parts []types.Part
+	for {
+		listResp, err := client.ListParts(ctx, listInput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parts for upload %s: %v", uploadID, err)
+		}
+		parts = append(parts, listResp.Parts...)
+		if !aws.ToBool(listResp.IsTruncated) {
+			break
+		}
+		listInput.PartNumberMarker = listResp.NextPartNumberMarker
+	}
+	return parts, nil
+}
+
+// AbortInput selects which incomplete uploads AbortIncompleteUploads sweeps.
+type AbortInput struct {
+	Bucket string
+	Prefix string
+	// OlderThan is the age threshold: uploads initiated more recently than
+	// this are left alone.
+	OlderThan time.Duration
+}
+
+// AbortIncompleteUploads aborts every incomplete multipart upload in Bucket
+// initiated more than OlderThan ago, so crashed or abandoned MultipartUpload
+// runs don't leak storage charges forever. It delegates the actual
+// list-paginate-and-abort sweep to ListAndAbortStaleUploads (the same sweep
+// the --abort-stale CLI flag uses) rather than re-implementing it, and
+// reports each abort as an Abort-stage UploadPhase, the same mechanism
+// MultipartUpload itself uses.
+func AbortIncompleteUploads(ctx context.Context, client *s3.Client, input AbortInput) (*UploadStatus, error) {
+	aborted, err := ListAndAbortStaleUploads(ctx, client, input.Bucket, input.Prefix, input.OlderThan, false)
+
+	status := &UploadStatus{}
+	for _, a := range aborted {
+		status.StartPhase(Abort, 0)
+		status.EndPhase(true, fmt.Sprintf("Aborted upload %s for key %s (initiated %s)", a.UploadID, a.Key, a.Initiated.Format(time.RFC3339)), nil)
+	}
+
+	return status, err
+}
@@ -15,12 +15,12 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -57,16 +57,19 @@ const (
 	PartUpload
 	Verification
 	Completion
-	DefaultPartSize  = 5 * 1024 * 1024 // 5MB default part size
-	minMultipartSize = 5 * 1024 * 1024 // 5MB minimum part size
-	greenColor       = "\033[32m"
-	yellowColor      = "\033[33m"
-	orangeColor      = "\033[38;5;208m" // Add orange color code
-	resetColor       = "\033[0m"
+	Abort
+	Resume
+	DefaultPartSize   = 5 * 1024 * 1024 // 5MB default part size
+	minMultipartSize  = 5 * 1024 * 1024 // 5MB minimum part size
+	defaultMaxWorkers = 4               // default concurrent part uploads when MaxWorkers is unset
+	greenColor        = "\033[32m"
+	yellowColor       = "\033[33m"
+	orangeColor       = "\033[38;5;208m" // Add orange color code
+	resetColor        = "\033[0m"
 )
 
 func (s UploadStage) String() string {
-	return [...]string{"upload initialization", "part upload", "verification", "completion"}[s]
+	return [...]string{"upload initialization", "part upload", "verification", "completion", "abort", "resume"}[s]
 }
 
 // UploadPhase represents a phase in the upload process
@@ -97,13 +100,31 @@ func (p UploadPhase) GetSummary() string {
 	return msg
 }
 
-// UploadStatus tracks the overall upload process
+// UploadStatus tracks the overall upload process. Part uploads happen
+// concurrently across worker goroutines, so StartPhase/EndPhase are
+// serialized by mu: each call pairs its own Start with its own End while
+// holding the lock, rather than relying on CurrentPhase being touched by one
+// goroutine at a time.
 type UploadStatus struct {
 	Phases       []UploadPhase
 	CurrentPhase *UploadPhase
+	mu           sync.Mutex
+
+	// Checksums holds the hex-encoded whole-object digests computed by
+	// MultiHasher for each of MultipartUploadInput.Algorithms, keyed by
+	// AlgorithmCRC32/AlgorithmSHA256/AlgorithmMD5. Populated on success only
+	// when every part was freshly read (not skipped via a checkpoint resume)
+	// and MultipartUploadInput.PartIndices is empty.
+	Checksums map[string]string
+	// CompositeETag is S3's composite multipart ETag ("<md5-of-md5s>-<n>"),
+	// set only when MD5 was among the requested Algorithms, every part
+	// was freshly read (i.e. not skipped via a checkpoint resume), and
+	// MultipartUploadInput.PartIndices is empty.
+	CompositeETag string
 }
 
 func (s *UploadStatus) StartPhase(stage UploadStage, partNumber int32) {
+	s.mu.Lock()
 	s.CurrentPhase = &UploadPhase{
 		Stage:      stage,
 		PartNumber: partNumber,
@@ -118,6 +139,7 @@ func (s *UploadStatus) EndPhase(success bool, message string, err error) {
 		s.Phases = append(s.Phases, *s.CurrentPhase)
 		s.CurrentPhase = nil
 	}
+	s.mu.Unlock()
 }
 
 func (s *UploadStatus) PrintSummary() {
@@ -139,6 +161,10 @@ func (e UploadError) Error() string {
 // ComputeCRC32 calculates CRC32 checksum for data
 func ComputeCRC32(data []byte) string {
 	crc32Val := crc32.ChecksumIEEE(data)
+	return encodeCRC32(crc32Val)
+}
+
+func encodeCRC32(crc32Val uint32) string {
 	crc32Bytes := make([]byte, 4)
 	for i := 3; i >= 0; i-- {
 		crc32Bytes[i] = byte(crc32Val)
@@ -151,8 +177,10 @@ func ComputeCRC32(data []byte) string {
 type MultipartUploadInput struct {
 	Bucket          string
 	Key             string
-	Data            []byte // For direct byte data
-	FilePath        string // For file path input
+	Data            []byte    // For direct byte data
+	FilePath        string    // For file path input
+	Reader          io.Reader // For streaming input of unknown or unbounded size
+	ReaderSize      int64     // Optional size hint for Reader, used for logging/progress and auto part sizing
 	EndpointURL     string
 	Region          string
 	Profile         string
@@ -160,6 +188,43 @@ type MultipartUploadInput struct {
 	UploadEmptyPart bool    // New field for controlling empty part upload
 	PartIndices     []int32 // New field for specifying which parts to upload
 	PartSize        int64   // Size of each part in bytes
+
+	// EnableCheckpoint persists upload progress to CheckpointPath so an
+	// interrupted upload can be resumed with ResumeMultipartUpload instead
+	// of starting over. Only supported for FilePath input.
+	EnableCheckpoint bool
+	CheckpointPath   string
+
+	// ChecksumAlgorithm selects the per-part and whole-object checksum
+	// algorithm: one of CRC32 (default), CRC32C, SHA1, or SHA256.
+	ChecksumAlgorithm string
+
+	// RetryPolicy controls per-part retry behavior on transient UploadPart
+	// failures. Zero-valued MaxAttempts/InitialBackoff/MaxBackoff fall back to
+	// defaultRetryPolicy; Jitter is used as given.
+	RetryPolicy RetryPolicy
+
+	// AutoPartSize marks that the part size is picked from the total input
+	// size. MultipartUpload sets it itself whenever PartSize is 0 and the
+	// total size is known. An explicit PartSize is always used as given;
+	// setting AutoPartSize alongside it only adds a check that the resulting
+	// part count stays within MaxParts.
+	AutoPartSize bool
+
+	// MaxParts overrides the part-count ceiling AutoPartSize sizes against.
+	// Defaults to S3's 10,000-part limit when 0; lowering it yields larger,
+	// fewer parts for the same totalSize.
+	MaxParts int
+
+	// MaxWorkers bounds how many parts upload concurrently. Defaults to
+	// defaultMaxWorkers when left at 0.
+	MaxWorkers int
+
+	// Algorithms selects which whole-object digests MultiHasher computes
+	// during the upload (AlgorithmCRC32, AlgorithmSHA256, AlgorithmMD5). A
+	// nil/empty slice computes all three. Unrelated to ChecksumAlgorithm,
+	// which drives S3's own per-part/whole-object checksum fields.
+	Algorithms []string
 }
 
 // Add struct to hold all profile settings
@@ -234,7 +299,11 @@ func getCredentialsFromProfile(profile string) (awsProfile, error) {
 }
 
 // Add new helper function to verify part checksums
-func verifyPartChecksums(ctx context.Context, client *s3.Client, bucket, key string, uploadID *string, data []byte, partSize int64, completedParts []types.CompletedPart) error {
+//
+// Verification is done against the checksums recorded while each part was
+// uploaded (partInfos), rather than by re-reading the source payload, since
+// the source may be a one-shot io.Reader that can no longer be replayed.
+func verifyPartChecksums(ctx context.Context, client *s3.Client, bucket, key string, uploadID *string, partInfos []PartInfo, hasher partHasher) error {
 	InfoLogger.Println("Listing parts for verification...")
 	partsOutput, err := client.ListParts(ctx, &s3.ListPartsInput{
 		Bucket:   aws.String(bucket),
@@ -247,7 +316,7 @@ func verifyPartChecksums(ctx context.Context, client *s3.Client, bucket, key str
 
 	InfoLogger.Printf("\nFound %d parts:\n", len(partsOutput.Parts))
 	InfoLogger.Println(strings.Repeat("-", 80))
-	InfoLogger.Printf("%-8s %-12s %-32s %-24s %s\n", "Part #", "Size", "ETag", "Last Modified", "Checksum (CRC32)")
+	InfoLogger.Printf("%-8s %-12s %-32s %-24s %s\n", "Part #", "Size", "ETag", "Last Modified", fmt.Sprintf("Checksum (%s)", hasher.Name()))
 	InfoLogger.Println(strings.Repeat("-", 80))
 
 	for _, part := range partsOutput.Parts {
@@ -256,33 +325,34 @@ func verifyPartChecksums(ctx context.Context, client *s3.Client, bucket, key str
 			part.Size,
 			aws.ToString(part.ETag),
 			part.LastModified.Format("2006-01-02 15:04:05 MST"),
-			aws.ToString(part.ChecksumCRC32),
+			aws.ToString(hasher.GetFromPart(part)),
 		)
 	}
 	InfoLogger.Println(strings.Repeat("-", 80))
 
-	if len(partsOutput.Parts) != len(completedParts) {
-		return fmt.Errorf("parts count mismatch: uploaded %d, listed %d", len(completedParts), len(partsOutput.Parts))
+	if len(partsOutput.Parts) != len(partInfos) {
+		return fmt.Errorf("parts count mismatch: uploaded %d, listed %d", len(partInfos), len(partsOutput.Parts))
+	}
+
+	expectedByPartNumber := make(map[int32]string, len(partInfos))
+	for _, info := range partInfos {
+		expectedByPartNumber[info.PartNumber] = info.Checksum
 	}
 
-	buffer := bytes.NewReader(data)
 	for _, part := range partsOutput.Parts {
-		partBuffer := make([]byte, partSize)
-		n, err := buffer.Read(partBuffer)
-		if err != nil && err != io.EOF {
-			return fmt.Errorf("error reading part data: %v", err)
+		expectedChecksum, ok := expectedByPartNumber[*part.PartNumber]
+		if !ok {
+			return fmt.Errorf("listed part %d was not among the uploaded parts", part.PartNumber)
 		}
 
-		partData := partBuffer[:n]
-		expectedChecksum := ComputeCRC32(partData)
-
-		if part.ChecksumCRC32 == nil {
-			return fmt.Errorf("part %d missing CRC32 checksum", part.PartNumber)
+		actualChecksum := hasher.GetFromPart(part)
+		if actualChecksum == nil {
+			return fmt.Errorf("part %d missing %s checksum", part.PartNumber, hasher.Name())
 		}
 
-		if *part.ChecksumCRC32 != expectedChecksum {
+		if *actualChecksum != expectedChecksum {
 			return fmt.Errorf("checksum mismatch for part %d: expected %s, got %s",
-				part.PartNumber, expectedChecksum, *part.ChecksumCRC32)
+				part.PartNumber, expectedChecksum, *actualChecksum)
 		}
 	}
 
@@ -294,6 +364,7 @@ type PartInfo struct {
 	PartNumber int32
 	Size       int64
 	Checksum   string
+	Retries    int // number of retry attempts beyond the first, via RetryPolicy
 }
 
 // Add new struct for part upload work
@@ -314,6 +385,26 @@ func uploadPart(ctx context.Context, client *s3.Client, input *s3.UploadPartInpu
 	return client.UploadPart(ctx, input)
 }
 
+// partSource abstracts over the ways an upload's payload can be supplied
+// (in-memory bytes, a file path, or an arbitrary io.Reader) behind a single
+// io.Reader so the upload loop never has to hold the whole object in memory.
+func partSource(input MultipartUploadInput) (io.Reader, func() error, error) {
+	switch {
+	case input.Reader != nil:
+		return input.Reader, func() error { return nil }, nil
+	case input.FilePath != "":
+		f, err := os.Open(input.FilePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to open file: %v", err)
+		}
+		return f, f.Close, nil
+	case input.Data != nil:
+		return bytes.NewReader(input.Data), func() error { return nil }, nil
+	default:
+		return nil, nil, fmt.Errorf("no data provided: either Data, FilePath, or Reader must be set")
+	}
+}
+
 // Modify MultipartUpload to track part info
 func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadStatus, error) {
 	if input.Verbose {
@@ -321,6 +412,7 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 		printVerbose("Input Configuration", map[string]interface{}{
 			"file":         input.FilePath,
 			"text":         input.Data != nil,
+			"reader":       input.Reader != nil,
 			"bucket":       input.Bucket,
 			"key":          input.Key,
 			"endpoint_url": input.EndpointURL,
@@ -332,173 +424,322 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 		})
 	}
 
-	// Handle file input
-	var data []byte
-	var err error
-	if input.FilePath != "" {
-		data, err = os.ReadFile(input.FilePath)
-		if err != nil {
-			return nil, fmt.Errorf("unable to read file: %v", err)
-		}
-	} else {
-		data = input.Data
+	source, closeSource, err := partSource(input)
+	if err != nil {
+		return nil, err
 	}
+	defer closeSource()
 
-	if len(data) == 0 {
-		return nil, fmt.Errorf("no data provided: either Data or FilePath must be set")
+	hasher, err := resolveChecksumAlgorithm(input.ChecksumAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	multiHasher, err := NewMultiHasher(input.Algorithms)
+	if err != nil {
+		return nil, err
 	}
 
 	status := &UploadStatus{}
 
-	// Load AWS configuration
-	var cfgOptions []func(*config.LoadOptions) error
+	client, err := newS3Client(ctx, input.Profile, &input.Region, &input.EndpointURL, input.Verbose)
+	if err != nil {
+		return nil, err
+	}
 
-	// Handle profile credentials if specified
-	if input.Profile != "" {
-		prof, err := getCredentialsFromProfile(input.Profile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get credentials from profile: %v", err)
+	var bytesUploaded int64 = 0
+	totalSize := input.ReaderSize
+	var sourceModTime time.Time
+	if input.FilePath != "" {
+		if fi, statErr := os.Stat(input.FilePath); statErr == nil {
+			totalSize = fi.Size()
+			sourceModTime = fi.ModTime()
 		}
+	} else if input.Data != nil {
+		totalSize = int64(len(input.Data))
+	}
 
-		// Use region from profile if available, otherwise use input region
-		if prof.region != "" {
-			input.Region = prof.region
-		}
-		// Use endpoint URL from profile if available, otherwise use input endpoint URL
-		if prof.endpointURL != "" {
-			input.EndpointURL = prof.endpointURL
-		}
+	// A FilePath or Data source with a known, genuinely empty size has no
+	// bytes to read a part from; queue it as a single empty part instead of
+	// silently producing zero parts and failing deep inside
+	// CompleteMultipartUpload with S3's opaque "must specify at least one
+	// part" error.
+	if (input.FilePath != "" || input.Data != nil) && totalSize == 0 && !input.UploadEmptyPart {
+		InfoLogger.Println("Source is empty; uploading a single empty part")
+		input.UploadEmptyPart = true
+	}
 
-		if input.Verbose {
-			InfoLogger.Printf("Using endpoint URL: %s\n", input.EndpointURL)
-			InfoLogger.Printf("Using region: %s\n", input.Region)
+	// Pick a part size. An explicit PartSize always wins; otherwise, if the
+	// total size is known, size parts so the upload stays under S3's
+	// 10,000-part limit rather than silently breaking past ~50 GiB with the
+	// fixed 5 MiB default.
+	var partSizeNote string
+	if input.PartSize == 0 {
+		if totalSize > 0 {
+			input.AutoPartSize = true
+			autoSize, err := computeAutoPartSize(totalSize, input.MaxParts)
+			if err != nil {
+				return nil, err
+			}
+			input.PartSize = autoSize
+			expectedParts := (totalSize + input.PartSize - 1) / input.PartSize
+			partSizeNote = fmt.Sprintf("auto-selected part size %d bytes (~%d parts for %d total bytes)", input.PartSize, expectedParts, totalSize)
+			InfoLogger.Println(partSizeNote)
+		} else {
+			input.PartSize = DefaultPartSize
 		}
-
-		cfgOptions = append(cfgOptions, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-			prof.accessKey,
-			prof.secretKey,
-			"",
-		)))
 	}
 
-	// Set region after profile processing
-	cfgOptions = append(cfgOptions, config.WithRegion(input.Region))
-
-	// Custom endpoint resolver
-	customResolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
-		if input.EndpointURL != "" {
-			return aws.Endpoint{
-				PartitionID:       "aws",
-				URL:               input.EndpointURL,
-				SigningRegion:     region,
-				HostnameImmutable: true,
-			}, nil
+	// Validate part size and, when auto-selected, the resulting part count.
+	if input.PartSize < minMultipartSize {
+		return nil, fmt.Errorf("part size must be at least %d bytes", minMultipartSize)
+	}
+	if input.AutoPartSize {
+		maxParts := input.MaxParts
+		if maxParts <= 0 {
+			maxParts = maxUploadParts
+		}
+		if expectedParts := (totalSize + input.PartSize - 1) / input.PartSize; expectedParts > int64(maxParts) {
+			return nil, fmt.Errorf("computed part count %d exceeds MaxParts %d for %d total bytes", expectedParts, maxParts, totalSize)
 		}
-		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-	})
-	cfgOptions = append(cfgOptions, config.WithEndpointResolver(customResolver))
-
-	cfg, err := config.LoadDefaultConfig(ctx, cfgOptions...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to load SDK config: %v", err)
 	}
 
-	client := s3.NewFromConfig(cfg)
+	checkpointing := input.EnableCheckpoint && input.CheckpointPath != "" && input.FilePath != ""
 
-	// Start upload process
-	InfoLogger.Println("\nInitiating multipart upload...")
-	status.StartPhase(Init, 0)
+	var uploadID *string
+	var checkpoint *checkpointState
+	var resumedParts []PartInfo
+	var resumedCompleted []completedPartRef
 
-	// Create multipart upload
-	createResp, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
-		Bucket:            aws.String(input.Bucket),
-		Key:               aws.String(input.Key),
-		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
-	})
-	if err != nil {
-		status.EndPhase(false, "Failed to initiate upload", err)
-		return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+	if checkpointing {
+		if existing, err := loadCheckpoint(input.CheckpointPath); err == nil {
+			fingerprint, fpErr := computeContentFingerprint(input.FilePath, checkpointFingerprintBytes)
+			if fpErr != nil {
+				return nil, fmt.Errorf("failed to fingerprint source for resume: %v", fpErr)
+			}
+			if existing.matchesSource(input, totalSize, sourceModTime, fingerprint) {
+				status.StartPhase(Resume, 0)
+				InfoLogger.Printf("Resuming upload %s from checkpoint %s\n", existing.UploadID, input.CheckpointPath)
+				parts, completed, err := reconcileCheckpoint(ctx, client, existing, hasher)
+				if err != nil {
+					status.EndPhase(false, "Failed to reconcile checkpoint", err)
+					return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+				}
+				uploadID = aws.String(existing.UploadID)
+				checkpoint = existing
+				resumedParts = parts
+				resumedCompleted = completed
+				for _, p := range parts {
+					bytesUploaded += p.Size
+				}
+				status.EndPhase(true, withPartSizeNote(fmt.Sprintf("Resumed with %d parts already uploaded", len(parts)), partSizeNote), nil)
+			}
+		}
 	}
 
-	if input.Verbose {
-		printVerbose("Create Multipart Upload Response", map[string]interface{}{
-			"Response": map[string]interface{}{
-				"Metadata": createResp.ResultMetadata,
-				"Body": map[string]interface{}{
-					"Bucket":   input.Bucket,
-					"Key":      input.Key,
-					"UploadId": createResp.UploadId,
-				},
-			},
+	if uploadID == nil {
+		// Start upload process
+		InfoLogger.Println("\nInitiating multipart upload...")
+		status.StartPhase(Init, 0)
+
+		// Create multipart upload
+		createResp, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:            aws.String(input.Bucket),
+			Key:               aws.String(input.Key),
+			ChecksumAlgorithm: hasher.SDKAlgorithm(),
 		})
-	}
+		if err != nil {
+			status.EndPhase(false, "Failed to initiate upload", err)
+			return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+		}
 
-	status.EndPhase(true, "Upload initiated successfully", nil)
-	uploadID := createResp.UploadId
+		if input.Verbose {
+			printVerbose("Create Multipart Upload Response", map[string]interface{}{
+				"Response": map[string]interface{}{
+					"Metadata": createResp.ResultMetadata,
+					"Body": map[string]interface{}{
+						"Bucket":   input.Bucket,
+						"Key":      input.Key,
+						"UploadId": createResp.UploadId,
+					},
+				},
+			})
+		}
 
-	// Set default part size if not specified
-	if input.PartSize == 0 {
-		input.PartSize = DefaultPartSize
-	}
+		status.EndPhase(true, withPartSizeNote("Upload initiated successfully", partSizeNote), nil)
+		uploadID = createResp.UploadId
 
-	// Validate part size
-	if input.PartSize < minMultipartSize {
-		return nil, fmt.Errorf("part size must be at least %d bytes", minMultipartSize)
+		if checkpointing {
+			fingerprint, err := computeContentFingerprint(input.FilePath, checkpointFingerprintBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fingerprint source for checkpoint: %v", err)
+			}
+			checkpoint = &checkpointState{
+				Bucket:             input.Bucket,
+				Key:                input.Key,
+				FilePath:           input.FilePath,
+				Region:             input.Region,
+				EndpointURL:        input.EndpointURL,
+				Profile:            input.Profile,
+				UploadID:           *uploadID,
+				PartSize:           input.PartSize,
+				ChecksumAlgorithm:  input.ChecksumAlgorithm,
+				SourceSize:         totalSize,
+				SourceModTime:      sourceModTime.UnixNano(),
+				ContentFingerprint: fingerprint,
+			}
+			if err := saveCheckpoint(input.CheckpointPath, checkpoint); err != nil {
+				InfoLogger.Printf("warning: failed to write checkpoint: %v\n", err)
+			}
+		}
 	}
 
-	// Calculate total size
-	totalSize := int64(len(data))
-	var bytesUploaded int64 = 0
+	// Abort the upload on any failure path below so S3 doesn't keep billing
+	// for orphaned parts. Checkpointed uploads are exempt: they're meant to be
+	// resumed, not thrown away, so a mid-upload failure there must leave the
+	// upload ID intact for ResumeMultipartUpload to pick back up.
+	completed := false
+	defer func() {
+		if completed || checkpointing {
+			return
+		}
+		status.StartPhase(Abort, 0)
+		_, abortErr := client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(input.Bucket),
+			Key:      aws.String(input.Key),
+			UploadId: uploadID,
+		})
+		if abortErr != nil {
+			status.EndPhase(false, "Failed to abort upload", abortErr)
+			return
+		}
+		status.EndPhase(true, "Upload aborted", nil)
+	}()
 
-	// Create work and result channels
-	numWorkers := 10 // Number of concurrent uploads
+	// Bound peak memory to roughly numWorkers*PartSize regardless of source
+	// size by recycling part buffers through a pool instead of allocating a
+	// fresh one per part.
+	partBufferPool := sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, input.PartSize)
+			return &buf
+		},
+	}
+
+	numWorkers := input.MaxWorkers
+	if numWorkers <= 0 {
+		numWorkers = defaultMaxWorkers
+	}
 	workChan := make(chan partUploadWork)
 	resultChan := make(chan partUploadResult)
-	errorChan := make(chan error, 1)
-	var wg sync.WaitGroup
 
-	// Start worker goroutines
+	// g's context is cancelled the moment any worker returns an error, so
+	// in-flight siblings stop uploading instead of racing to finish parts
+	// nobody needs; the abort defer above still uses the outer, uncancelled
+	// ctx so AbortMultipartUpload isn't skipped because gctx already died.
+	g, gctx := errgroup.WithContext(ctx)
+
+	// partPhaseStart marks where PartUpload phases begin in status.Phases, so
+	// they can be sorted back into PartNumber order below despite completing
+	// out of order across workers.
+	partPhaseStart := len(status.Phases)
+
+	// failedPhase records the UploadPhase for whichever worker actually
+	// failed. It's built at the failure site rather than read back from
+	// status.Phases afterward, because a sibling worker can still be mid-
+	// flight when gctx is cancelled and append its own (successful) phase
+	// after the failing one, making "the last phase in the slice" unreliable.
+	var failMu sync.Mutex
+	var failedPhase *UploadPhase
+
 	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for work := range workChan {
-				uploadResp, err := uploadPart(ctx, client, &s3.UploadPartInput{
-					Bucket:        aws.String(input.Bucket),
-					Key:           aws.String(input.Key),
-					PartNumber:    aws.Int32(work.partNumber),
-					UploadId:      uploadID,
-					Body:          bytes.NewReader(work.data),
-					ChecksumCRC32: aws.String(work.checksum),
-				})
+		g.Go(func() error {
+			for {
+				select {
+				case <-gctx.Done():
+					return gctx.Err()
+				case work, ok := <-workChan:
+					if !ok {
+						return nil
+					}
 
-				if err != nil {
-					select {
-					case errorChan <- err:
-					default:
+					uploadPartInput := &s3.UploadPartInput{
+						Bucket:     aws.String(input.Bucket),
+						Key:        aws.String(input.Key),
+						PartNumber: aws.Int32(work.partNumber),
+						UploadId:   uploadID,
+						Body:       bytes.NewReader(work.data),
+					}
+					hasher.SetOnUploadPart(uploadPartInput, work.checksum)
+
+					uploadResp, retries, err := uploadPartWithRetry(gctx, client, uploadPartInput, input.RetryPolicy)
+
+					buf := work.data[:cap(work.data)]
+					partBufferPool.Put(&buf)
+
+					// The phase is opened only once the upload has finished:
+					// StartPhase holds status.mu until EndPhase, so opening it
+					// before the upload would let only one part upload at a time.
+					status.StartPhase(PartUpload, work.partNumber)
+					if err != nil {
+						status.EndPhase(false, "Failed to upload part", err)
+						failMu.Lock()
+						if failedPhase == nil {
+							failedPhase = &UploadPhase{
+								Stage:      PartUpload,
+								Success:    false,
+								Message:    "Failed to upload part",
+								PartNumber: work.partNumber,
+								Error:      err,
+							}
+						}
+						failMu.Unlock()
+						return fmt.Errorf("part %d: %w", work.partNumber, err)
 					}
-					continue
-				}
 
-				resultChan <- partUploadResult{
-					part: types.CompletedPart{
-						ETag:          uploadResp.ETag,
-						PartNumber:    aws.Int32(work.partNumber),
-						ChecksumCRC32: aws.String(work.checksum),
-					},
-					info: PartInfo{
-						PartNumber: work.partNumber,
-						Size:       int64(len(work.data)),
-						Checksum:   work.checksum,
-					},
+					completedPart := types.CompletedPart{
+						ETag:       uploadResp.ETag,
+						PartNumber: aws.Int32(work.partNumber),
+					}
+					hasher.SetOnCompletedPart(&completedPart, work.checksum)
+					status.EndPhase(true, fmt.Sprintf("Uploaded and verified (part %d, %d bytes, %d retries)", work.partNumber, len(work.data), retries), nil)
+
+					select {
+					case resultChan <- partUploadResult{
+						part: completedPart,
+						info: PartInfo{
+							PartNumber: work.partNumber,
+							Size:       int64(len(work.data)),
+							Checksum:   work.checksum,
+							Retries:    retries,
+						},
+					}:
+					case <-gctx.Done():
+						return gctx.Err()
+					}
 				}
 			}
-		}()
+		})
 	}
 
-	// Start result collector
+	// Seed with parts already confirmed on S3 from a resumed checkpoint, so
+	// they're verified and completed alongside newly uploaded parts without
+	// being re-uploaded.
 	var allCompletedParts []types.CompletedPart
 	var partInfos []PartInfo
+	for _, info := range resumedParts {
+		partInfos = append(partInfos, info)
+	}
+	for _, c := range resumedCompleted {
+		completedPart := types.CompletedPart{
+			ETag:       aws.String(c.ETag),
+			PartNumber: aws.Int32(c.PartNumber),
+		}
+		hasher.SetOnCompletedPart(&completedPart, c.Checksum)
+		allCompletedParts = append(allCompletedParts, completedPart)
+	}
+
+	// Start result collector
 	resultDone := make(chan bool)
 	go func() {
 		for result := range resultChan {
@@ -506,39 +747,72 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 			allCompletedParts = append(allCompletedParts, result.part)
 			partInfos = append(partInfos, result.info)
 			bytesUploaded += result.info.Size
-			status.EndPhase(true, fmt.Sprintf("Uploaded and verified (%d/%d bytes)", bytesUploaded, totalSize), nil)
 			InfoLogger.Printf("✓ Part %d uploaded and verified (%d/%d bytes)\n",
 				result.info.PartNumber, bytesUploaded, totalSize)
+
+			if checkpointing {
+				checkpoint.CompletedParts = append(checkpoint.CompletedParts, checkpointPart{
+					PartNumber: result.info.PartNumber,
+					Size:       result.info.Size,
+					Checksum:   result.info.Checksum,
+					ETag:       aws.ToString(result.part.ETag),
+				})
+				if err := saveCheckpoint(input.CheckpointPath, checkpoint); err != nil {
+					InfoLogger.Printf("warning: failed to update checkpoint: %v\n", err)
+				}
+			}
 		}
 		resultDone <- true
 	}()
 
-	// Send work (remove the part indices check here - we upload all parts)
-	buffer := bytes.NewReader(data)
+	// Skip parts already confirmed on S3 by a resumed checkpoint.
+	// reconcileCheckpoint guarantees resumedParts is trimmed to the
+	// contiguous prefix 1..len(resumedParts), so counting elements here
+	// (rather than tracking each part's actual PartNumber) is safe.
 	partNumber := int32(1)
+	for range resumedParts {
+		if err := skipSourceBytes(source, input.PartSize); err != nil {
+			close(workChan)
+			return nil, fmt.Errorf("error skipping already-uploaded part %d: %v", partNumber, err)
+		}
+		partNumber++
+	}
 
+	// firstPartNumber lets us tell, after readLoop, whether it queued
+	// anything at all. A Reader source's size is usually unknown upfront
+	// (ReaderSize is only an optional hint), so unlike FilePath/Data an empty
+	// Reader can't be detected before reading; this instead detects it
+	// after the fact, by readLoop producing no parts.
+	firstPartNumber := partNumber
+
+	// Read parts incrementally from source so peak memory stays bounded by
+	// numWorkers*PartSize regardless of the total payload size. Producing
+	// stops early if gctx is cancelled by a worker error below.
+readLoop:
 	for {
-		partBuffer := make([]byte, input.PartSize)
-		n, err := buffer.Read(partBuffer)
+		bufPtr := partBufferPool.Get().(*[]byte)
+		partBuffer := *bufPtr
+		n, err := io.ReadFull(source, partBuffer)
 		if err == io.EOF {
+			partBufferPool.Put(bufPtr)
 			break
 		}
-		if err != nil {
+		if err != nil && err != io.ErrUnexpectedEOF {
+			partBufferPool.Put(bufPtr)
 			close(workChan)
 			return nil, fmt.Errorf("error reading part: %v", err)
 		}
 
 		partData := partBuffer[:n]
-		checksum := ComputeCRC32(partData)
+		checksum := hasher.Sum(partData)
+		multiHasher.WritePart(partData)
 
 		InfoLogger.Printf("Queueing part %d...\n", partNumber)
-		status.StartPhase(PartUpload, partNumber)
 
 		select {
-		case err := <-errorChan:
-			close(workChan)
-			status.EndPhase(false, "Failed to upload part", err)
-			return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+		case <-gctx.Done():
+			partBufferPool.Put(bufPtr)
+			break readLoop
 		case workChan <- partUploadWork{
 			partNumber: partNumber,
 			data:       partData,
@@ -547,21 +821,33 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 		}
 
 		partNumber++
+
+		// io.ReadFull returning ErrUnexpectedEOF means this was the final,
+		// short part; nothing more to read from source.
+		if err == io.ErrUnexpectedEOF {
+			break
+		}
+	}
+
+	// A Reader source that turned out to have zero bytes has queued nothing
+	// so far; fall back to a single empty part just like a known-empty
+	// FilePath/Data source does above, instead of calling
+	// CompleteMultipartUpload with no parts at all.
+	if input.Reader != nil && partNumber == firstPartNumber && len(resumedParts) == 0 && !input.UploadEmptyPart {
+		InfoLogger.Println("Reader produced no data; uploading a single empty part")
+		input.UploadEmptyPart = true
 	}
 
 	// Handle empty part if requested
 	if input.UploadEmptyPart {
 		emptyData := []byte{}
-		checksum := ComputeCRC32(emptyData)
+		checksum := hasher.Sum(emptyData)
+		multiHasher.WritePart(emptyData)
 
 		InfoLogger.Printf("Queueing final empty part %d...\n", partNumber)
-		status.StartPhase(PartUpload, partNumber)
 
 		select {
-		case err := <-errorChan:
-			close(workChan)
-			status.EndPhase(false, "Failed to upload empty part", err)
-			return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+		case <-gctx.Done():
 		case workChan <- partUploadWork{
 			partNumber: partNumber,
 			data:       emptyData,
@@ -570,12 +856,29 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 		}
 	}
 
-	// Close channels and wait for completion
+	// Close channels and wait for all workers to finish (or short-circuit on
+	// the first error, via gctx).
 	close(workChan)
-	wg.Wait()
+	groupErr := g.Wait()
 	close(resultChan)
 	<-resultDone
 
+	if groupErr != nil {
+		if failedPhase != nil {
+			return status, &UploadError{Phase: *failedPhase}
+		}
+		return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+	}
+
+	// Workers complete parts out of order; restore PartNumber order so the
+	// summary reads top-to-bottom like a sequential upload would.
+	if len(status.Phases) > partPhaseStart {
+		partPhases := status.Phases[partPhaseStart:]
+		sort.SliceStable(partPhases, func(i, j int) bool {
+			return partPhases[i].PartNumber < partPhases[j].PartNumber
+		})
+	}
+
 	// Filter completed parts based on user-specified indices
 	var completedParts []types.CompletedPart
 	if len(input.PartIndices) > 0 {
@@ -607,7 +910,7 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 	InfoLogger.Println("\nVerifying uploaded parts...")
 	status.StartPhase(Verification, 0)
 
-	err = verifyPartChecksums(ctx, client, input.Bucket, input.Key, uploadID, data, input.PartSize, allCompletedParts)
+	err = verifyPartChecksums(ctx, client, input.Bucket, input.Key, uploadID, partInfos, hasher)
 	if err != nil {
 		status.EndPhase(false, "Failed to verify parts", err)
 		return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
@@ -625,15 +928,30 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 		return *completedParts[i].PartNumber < *completedParts[j].PartNumber
 	})
 
-	completeResp, err := client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+	sort.Slice(partInfos, func(i, j int) bool {
+		return partInfos[i].PartNumber < partInfos[j].PartNumber
+	})
+	partChecksumsInOrder := make([]string, 0, len(partInfos))
+	for _, info := range partInfos {
+		partChecksumsInOrder = append(partChecksumsInOrder, info.Checksum)
+	}
+	objectChecksum, err := hasher.Combine(partChecksumsInOrder)
+	if err != nil {
+		status.EndPhase(false, "Failed to compute object checksum", err)
+		return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
+	}
+
+	completeInput := &s3.CompleteMultipartUploadInput{
 		Bucket:   aws.String(input.Bucket),
 		Key:      aws.String(input.Key),
 		UploadId: uploadID,
 		MultipartUpload: &types.CompletedMultipartUpload{
 			Parts: completedParts,
 		},
-		ChecksumCRC32: aws.String(ComputeCRC32(data)),
-	})
+	}
+	hasher.SetOnComplete(completeInput, objectChecksum)
+
+	completeResp, err := client.CompleteMultipartUpload(ctx, completeInput)
 	if err != nil {
 		status.EndPhase(false, "Failed to complete upload", err)
 		return status, &UploadError{Phase: status.Phases[len(status.Phases)-1]}
@@ -644,23 +962,45 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 			"Response": map[string]interface{}{
 				"Metadata": completeResp.ResultMetadata,
 				"Body": map[string]interface{}{
-					"Location":      completeResp.Location,
-					"Bucket":        input.Bucket,
-					"Key":           input.Key,
-					"ETag":          completeResp.ETag,
-					"VersionId":     completeResp.VersionId,
-					"ChecksumCRC32": completeResp.ChecksumCRC32,
+					"Location":  completeResp.Location,
+					"Bucket":    input.Bucket,
+					"Key":       input.Key,
+					"ETag":      completeResp.ETag,
+					"VersionId": completeResp.VersionId,
+					"Checksum":  objectChecksum,
 				},
 			},
 		})
 	}
 
+	completed = true
 	status.EndPhase(true, "Upload completed successfully", nil)
 	InfoLogger.Printf("✓ Upload completed: %s → %s/%s\n", "data input", input.Bucket, input.Key)
 
+	if checkpointing {
+		if err := deleteCheckpoint(input.CheckpointPath); err != nil {
+			InfoLogger.Printf("warning: failed to remove checkpoint: %v\n", err)
+		}
+	}
+
+	// Both the whole-object checksums and the composite ETag only match the
+	// real object when every part was freshly read (not resumed via
+	// skipSourceBytes) and none were filtered out by PartIndices, since both
+	// of those change which bytes actually make up the final object.
+	if len(input.PartIndices) == 0 {
+		if sums, err := multiHasher.Sums(len(partInfos)); err == nil {
+			status.Checksums = sums
+		} else {
+			InfoLogger.Printf("warning: whole-object checksums unavailable: %v\n", err)
+		}
+		if etag, err := multiHasher.CompositeETag(len(partInfos)); err == nil {
+			status.CompositeETag = etag
+		}
+	}
+
 	// Print checksums summary
 	InfoLogger.Println("\n=== Checksums Summary ===")
-	InfoLogger.Printf("%s%-8s %-12s %-15s %s%s\n", greenColor, "Part #", "Size (bytes)", "Status", "Checksum (CRC32)", resetColor)
+	InfoLogger.Printf("%s%-8s %-12s %-15s %-8s %s%s\n", greenColor, "Part #", "Size (bytes)", "Status", "Retries", fmt.Sprintf("Checksum (%s)", hasher.Name()), resetColor)
 	InfoLogger.Println(strings.Repeat("-", 80))
 
 	// Create a map of included part numbers for quick lookup
@@ -669,10 +1009,6 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 		includedParts[*part.PartNumber] = true
 	}
 
-	sort.Slice(partInfos, func(i, j int) bool {
-		return partInfos[i].PartNumber < partInfos[j].PartNumber
-	})
-
 	for _, part := range partInfos {
 		status := "skipped"
 		color := resetColor
@@ -681,12 +1017,13 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 			color = orangeColor
 		}
 
-		InfoLogger.Printf("%s%-8d %-12d %-15s%s %s%s%s\n",
+		InfoLogger.Printf("%s%-8d %-12d %-15s%s %-8d %s%s%s\n",
 			color,
 			part.PartNumber,
 			part.Size,
 			status,
 			resetColor,
+			part.Retries,
 			yellowColor,
 			part.Checksum,
 			resetColor,
@@ -694,14 +1031,13 @@ func MultipartUpload(ctx context.Context, input MultipartUploadInput) (*UploadSt
 	}
 
 	InfoLogger.Println(strings.Repeat("-", 80))
-	if completeResp.ChecksumCRC32 != nil {
-		InfoLogger.Printf("%sFinal object CRC32: %s%s%s\n",
-			greenColor,
-			yellowColor,
-			*completeResp.ChecksumCRC32,
-			resetColor,
-		)
-	}
+	InfoLogger.Printf("%sFinal object %s: %s%s%s\n",
+		greenColor,
+		hasher.Name(),
+		yellowColor,
+		objectChecksum,
+		resetColor,
+	)
 	InfoLogger.Println()
 
 	status.PrintSummary()
@@ -2,6 +2,8 @@ package s3_integrity_checks
 
 import (
 	"context"
+	"crypto/md5"
+	"fmt"
 	"io"
 	"testing"
 
@@ -58,6 +60,54 @@ func TestUploadPhaseGetSummary(t *testing.T) {
 	}
 }
 
+func TestComputeAutoPartSizeStaysUnderPartLimit(t *testing.T) {
+	const hundredGiB = 100 * 1024 * 1024 * 1024
+
+	partSize, err := computeAutoPartSize(hundredGiB, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11*1024*1024), partSize)
+
+	parts := (hundredGiB + partSize - 1) / partSize
+	assert.LessOrEqual(t, parts, int64(maxUploadParts))
+}
+
+func TestComputeAutoPartSizeTooLarge(t *testing.T) {
+	_, err := computeAutoPartSize(maxObjectSize+1, 0)
+	assert.Error(t, err)
+	assert.IsType(t, &ErrObjectTooLarge{}, err)
+}
+
+// TestMultiHasherCompositeETag verifies the composite ETag formula against
+// S3's own documented behavior: MD5 of the concatenated per-part MD5 digests,
+// followed by "-<part count>". This is the same value S3/LocalStack return
+// as the ETag for a multipart object uploaded with these exact part bytes.
+func TestMultiHasherCompositeETag(t *testing.T) {
+	part1 := []byte("first part data")
+	part2 := []byte("second part data")
+
+	h, err := NewMultiHasher(nil)
+	assert.NoError(t, err)
+	h.WritePart(part1)
+	h.WritePart(part2)
+
+	etag, err := h.CompositeETag(2)
+	assert.NoError(t, err)
+
+	sum1 := md5.Sum(part1)
+	sum2 := md5.Sum(part2)
+	want := fmt.Sprintf("%x-%d", md5.Sum(append(sum1[:], sum2[:]...)), 2)
+	assert.Equal(t, want, etag)
+}
+
+func TestMultiHasherCompositeETagRequiresMD5(t *testing.T) {
+	h, err := NewMultiHasher([]string{AlgorithmCRC32})
+	assert.NoError(t, err)
+	h.WritePart([]byte("data"))
+
+	_, err = h.CompositeETag(1)
+	assert.Error(t, err)
+}
+
 func TestMultipartUploadFailure(t *testing.T) {
 	ctx := context.Background()
 	input := MultipartUploadInput{
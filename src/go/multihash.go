@@ -0,0 +1,117 @@
+package s3_integrity_checks
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// Supported values for MultipartUploadInput.Algorithms.
+const (
+	AlgorithmCRC32  = "crc32"
+	AlgorithmSHA256 = "sha256"
+	AlgorithmMD5    = "md5"
+)
+
+// defaultAlgorithms is used when MultipartUploadInput.Algorithms is empty.
+var defaultAlgorithms = []string{AlgorithmCRC32, AlgorithmSHA256, AlgorithmMD5}
+
+// MultiHasher computes several whole-object digests in a single streaming
+// pass over part data, modeled on GitLab Workhorse's multi_hash.go, so the
+// uploader doesn't have to re-read the payload once per algorithm. It also
+// records the per-part MD5 digests needed to reconstruct S3's composite
+// multipart ETag, which is always MD5-based regardless of ChecksumAlgorithm.
+type MultiHasher struct {
+	whole        io.Writer
+	hashes       map[string]hash.Hash
+	partMD5s     [][]byte
+	partsWritten int
+}
+
+// NewMultiHasher builds a MultiHasher computing the requested algorithms
+// (AlgorithmCRC32, AlgorithmSHA256, AlgorithmMD5). A nil or empty algorithms
+// slice computes all three.
+func NewMultiHasher(algorithms []string) (*MultiHasher, error) {
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms
+	}
+
+	hashes := make(map[string]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+	for _, alg := range algorithms {
+		var h hash.Hash
+		switch alg {
+		case AlgorithmCRC32:
+			h = crc32.NewIEEE()
+		case AlgorithmSHA256:
+			h = sha256.New()
+		case AlgorithmMD5:
+			h = md5.New()
+		default:
+			return nil, fmt.Errorf("unsupported multi-hash algorithm %q", alg)
+		}
+		hashes[alg] = h
+		writers = append(writers, h)
+	}
+
+	return &MultiHasher{
+		whole:  io.MultiWriter(writers...),
+		hashes: hashes,
+	}, nil
+}
+
+// WritePart feeds one part's data into the whole-object digests and, if MD5
+// is among the requested algorithms, records that part's own MD5 for later
+// composite ETag reconstruction. Parts must be written in part-number order.
+func (m *MultiHasher) WritePart(data []byte) {
+	m.whole.Write(data)
+	m.partsWritten++
+	if _, ok := m.hashes[AlgorithmMD5]; ok {
+		sum := md5.Sum(data)
+		m.partMD5s = append(m.partMD5s, sum[:])
+	}
+}
+
+// Sums returns the hex-encoded whole-object digest for each requested
+// algorithm. It returns an error if partCount doesn't match the number of
+// parts actually written (e.g. because a checkpoint resume skipped
+// re-reading some parts via skipSourceBytes instead of WritePart), since a
+// digest over a partial read of the object isn't the whole-object digest it
+// claims to be.
+func (m *MultiHasher) Sums(partCount int) (map[string]string, error) {
+	if m.partsWritten != partCount {
+		return nil, fmt.Errorf("checksums need all %d parts hashed, only saw %d (likely a resumed upload)", partCount, m.partsWritten)
+	}
+
+	sums := make(map[string]string, len(m.hashes))
+	for alg, h := range m.hashes {
+		sums[alg] = hex.EncodeToString(h.Sum(nil))
+	}
+	return sums, nil
+}
+
+// CompositeETag reconstructs S3's ETag for a multipart object: the hex MD5
+// of the concatenated raw per-part MD5 digests, followed by "-<part count>".
+// It returns an error if MD5 wasn't requested, or if partCount doesn't match
+// the number of parts actually written (e.g. because a checkpoint resume
+// skipped re-reading some parts), since a partial set can't reconstruct the
+// real ETag.
+func (m *MultiHasher) CompositeETag(partCount int) (string, error) {
+	if _, ok := m.hashes[AlgorithmMD5]; !ok {
+		return "", fmt.Errorf("composite ETag requires the %q algorithm", AlgorithmMD5)
+	}
+	if len(m.partMD5s) != partCount {
+		return "", fmt.Errorf("composite ETag needs all %d parts hashed, only saw %d (likely a resumed upload)", partCount, len(m.partMD5s))
+	}
+
+	var concatenated []byte
+	for _, digest := range m.partMD5s {
+		concatenated = append(concatenated, digest...)
+	}
+	sum := md5.Sum(concatenated)
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), partCount), nil
+}
@@ -0,0 +1,60 @@
+package s3_integrity_checks
+
+import "fmt"
+
+const (
+	maxUploadParts = 10000                         // S3's hard cap on parts per multipart upload
+	maxPartSize    = 5 * 1024 * 1024 * 1024        // 5 GiB, S3's per-part maximum
+	maxObjectSize  = 5 * 1024 * 1024 * 1024 * 1024 // 5 TiB, S3's object size ceiling
+	mib            = 1024 * 1024
+)
+
+// ErrObjectTooLarge is returned by computeAutoPartSize when totalSize exceeds
+// S3's 5 TiB object ceiling, so callers get a clear failure up front instead
+// of a confusing error from CompleteMultipartUpload.
+type ErrObjectTooLarge struct {
+	TotalSize int64
+}
+
+func (e *ErrObjectTooLarge) Error() string {
+	return fmt.Sprintf("object size %d bytes exceeds S3's %d byte (5 TiB) limit", e.TotalSize, int64(maxObjectSize))
+}
+
+// withPartSizeNote appends note (if any) to an Init-stage phase message, so
+// the chosen part size/count is visible in the upload summary.
+func withPartSizeNote(message, note string) string {
+	if note == "" {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, note)
+}
+
+// computeAutoPartSize picks a part size for totalSize so the upload stays
+// within maxParts (S3's 10,000-part limit if maxParts is 0), rounded up to a
+// MiB boundary and capped at the 5 GiB per-part maximum.
+func computeAutoPartSize(totalSize int64, maxParts int) (int64, error) {
+	if totalSize > maxObjectSize {
+		return 0, &ErrObjectTooLarge{TotalSize: totalSize}
+	}
+
+	if maxParts <= 0 {
+		maxParts = maxUploadParts
+	}
+
+	partSize := int64(minMultipartSize)
+	if totalSize > 0 {
+		needed := (totalSize + int64(maxParts) - 1) / int64(maxParts)
+		if needed > partSize {
+			partSize = needed
+		}
+	}
+
+	// Round up to a MiB boundary.
+	partSize = ((partSize + mib - 1) / mib) * mib
+
+	if partSize > maxPartSize {
+		partSize = maxPartSize
+	}
+
+	return partSize, nil
+}
@@ -0,0 +1,156 @@
+package s3_integrity_checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// RetryPolicy controls how UploadPart is retried on transient failures. The
+// zero value is not used directly; uploadPartWithRetry fills in
+// defaultRetryPolicy for MaxAttempts, InitialBackoff and MaxBackoff when left
+// unset. Jitter is used as given, so a zero-valued policy runs without jitter.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts including the first, default 3
+	InitialBackoff time.Duration // delay before the first retry, default 200ms
+	MaxBackoff     time.Duration // backoff ceiling, default 5s
+	Jitter         bool          // randomize each delay within +/-50% to avoid thundering herds
+}
+
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         true,
+	}
+}
+
+// resolveRetryPolicy fills in zero-valued MaxAttempts, InitialBackoff and
+// MaxBackoff with defaultRetryPolicy's values, so callers can override just
+// the fields they care about. Jitter is left as the caller set it.
+func resolveRetryPolicy(policy RetryPolicy) RetryPolicy {
+	defaults := defaultRetryPolicy()
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	return policy
+}
+
+// fastFailErrorCodes are S3 API error codes that indicate a request the
+// client will never succeed by retrying unchanged (bad input, permissions,
+// a part too small to be anything but the last one).
+var fastFailErrorCodes = map[string]bool{
+	"InvalidRequest":  true,
+	"AccessDenied":    true,
+	"EntityTooSmall":  true,
+	"InvalidArgument": true,
+	"NoSuchUpload":    true,
+	"NoSuchBucket":    true,
+}
+
+// isRetryableError classifies an UploadPart error as transient (network
+// blip, 5xx, throttling) versus permanent (4xx the caller can't fix by
+// retrying the same request).
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		if fastFailErrorCodes[code] {
+			return false
+		}
+		if code == "SlowDown" || code == "RequestTimeout" || code == "RequestTimeTooSkewed" ||
+			code == "InternalError" || code == "ServiceUnavailable" {
+			return true
+		}
+	}
+
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		status := respErr.HTTPStatusCode()
+		if status == 429 || status == 408 {
+			return true
+		}
+		if status >= 500 {
+			return true
+		}
+		if status >= 400 {
+			return false
+		}
+	}
+
+	// Unwrapped network errors and anything else we can't classify are
+	// assumed transient, matching the worker's prior all-errors-fatal
+	// behavior only on the final attempt.
+	return true
+}
+
+// uploadPartWithRetry wraps uploadPart in policy's retry loop, honoring
+// ctx.Done() between attempts and returning the number of retries performed
+// alongside the final result.
+//
+// input.Body is re-seeked to the start before every attempt after the first:
+// a body that reached the network on a prior attempt is left at EOF, so
+// retrying without resetting it would serialize an empty/truncated part
+// while still advertising the checksum of the full part.
+func uploadPartWithRetry(ctx context.Context, client *s3.Client, input *s3.UploadPartInput, policy RetryPolicy) (*s3.UploadPartOutput, int, error) {
+	policy = resolveRetryPolicy(policy)
+
+	seeker, _ := input.Body.(io.Seeker)
+
+	var lastErr error
+	backoff := policy.InitialBackoff
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := backoff
+			if policy.Jitter {
+				delay = time.Duration(float64(delay) * (0.5 + rand.Float64()))
+			}
+			select {
+			case <-ctx.Done():
+				return nil, attempt, ctx.Err()
+			case <-time.After(delay):
+			}
+			backoff *= 2
+			if backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+
+			if seeker == nil {
+				return nil, attempt, fmt.Errorf("part body does not support Seek, cannot retry safely")
+			}
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, attempt, fmt.Errorf("failed to reset part body for retry: %v", err)
+			}
+		}
+
+		resp, err := uploadPart(ctx, client, input)
+		if err == nil {
+			return resp, attempt, nil
+		}
+		lastErr = err
+
+		if !isRetryableError(err) {
+			return nil, attempt, err
+		}
+	}
+
+	return nil, policy.MaxAttempts - 1, lastErr
+}
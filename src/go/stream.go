@@ -0,0 +1,45 @@
+package s3_integrity_checks
+
+import (
+	"context"
+	"io"
+)
+
+// MultipartUploadStreamInput is MultipartUploadInput's streaming-only
+// counterpart: Body/Size replace the Data/FilePath/Reader/ReaderSize choice
+// for callers who only ever have an io.Reader (e.g. piping an HTTP request
+// body straight to S3) and don't want the wider struct's unrelated fields.
+type MultipartUploadStreamInput struct {
+	Bucket      string
+	Key         string
+	Body        io.Reader
+	Size        int64 // optional size hint, used for logging/progress and auto part sizing
+	EndpointURL string
+	Region      string
+	Profile     string
+	Verbose     bool
+	PartSize    int64
+
+	ChecksumAlgorithm string
+	RetryPolicy       RetryPolicy
+}
+
+// MultipartUploadStream uploads from an io.Reader without requiring the
+// caller to buffer it first. It's a thin wrapper over MultipartUpload's
+// existing Reader/ReaderSize support, preserving the same UploadPhase/
+// UploadStatus reporting.
+func MultipartUploadStream(ctx context.Context, input MultipartUploadStreamInput) (*UploadStatus, error) {
+	return MultipartUpload(ctx, MultipartUploadInput{
+		Bucket:            input.Bucket,
+		Key:               input.Key,
+		Reader:            input.Body,
+		ReaderSize:        input.Size,
+		EndpointURL:       input.EndpointURL,
+		Region:            input.Region,
+		Profile:           input.Profile,
+		Verbose:           input.Verbose,
+		PartSize:          input.PartSize,
+		ChecksumAlgorithm: input.ChecksumAlgorithm,
+		RetryPolicy:       input.RetryPolicy,
+	})
+}